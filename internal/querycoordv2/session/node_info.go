@@ -0,0 +1,129 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+// NodeStats holds the load metrics a LeaderScorer uses to rank shard leader
+// candidates: inflight search pressure, resource usage and recent tail
+// latency. Segment count is not held here; scorers take it from the
+// leader view.
+type NodeStats struct {
+	InflightSearchCount int
+	CPUUsage            float64
+	MemoryUsage         float64
+	SearchLatencyP99    time.Duration
+}
+
+// NodeInfo is the QueryCoord-side view of a registered QueryNode: its
+// address plus the metrics and topology labels (zone, rack, region, ...)
+// reported at registration time.
+//
+// Scope note: this package is a minimal foundation for the zone-aware
+// leader-selection and load-scoring features in utils - nothing in this tree
+// actually calls SetLabels/SetStats from QueryNode registration or heartbeat
+// handling (that session-management code doesn't exist in this tree). Until
+// it's wired up, every NodeInfo is populated and mutated only by tests and by
+// whatever callers construct one directly.
+type NodeInfo struct {
+	mu      sync.RWMutex
+	id      int64
+	address string
+	labels  map[string]string
+	stats   NodeStats
+}
+
+// NewNodeInfo builds a NodeInfo for a freshly registered QueryNode.
+func NewNodeInfo(id int64, address string, labels map[string]string) *NodeInfo {
+	return &NodeInfo{id: id, address: address, labels: labels}
+}
+
+// ID returns the QueryNode's node ID.
+func (n *NodeInfo) ID() int64 {
+	return n.id
+}
+
+// Addr returns the QueryNode's gRPC address.
+func (n *NodeInfo) Addr() string {
+	return n.address
+}
+
+// Labels returns the topology labels (e.g. "zone", "rack", "region")
+// reported by the QueryNode at registration time. The returned map must not
+// be mutated by callers.
+func (n *NodeInfo) Labels() map[string]string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.labels
+}
+
+// SetLabels replaces the node's topology labels, e.g. on re-registration.
+func (n *NodeInfo) SetLabels(labels map[string]string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.labels = labels
+}
+
+// Stats returns the node's most recently reported load metrics.
+func (n *NodeInfo) Stats() NodeStats {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.stats
+}
+
+// SetStats updates the node's load metrics, e.g. from a heartbeat response.
+func (n *NodeInfo) SetStats(stats NodeStats) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.stats = stats
+}
+
+// NodeManager tracks the NodeInfo of every QueryNode known to QueryCoord.
+type NodeManager struct {
+	mu    sync.RWMutex
+	nodes map[int64]*NodeInfo
+}
+
+// NewNodeManager returns an empty NodeManager.
+func NewNodeManager() *NodeManager {
+	return &NodeManager{nodes: make(map[int64]*NodeInfo)}
+}
+
+// Get returns the NodeInfo for nodeID, or nil if the node is not (or no
+// longer) registered.
+func (m *NodeManager) Get(nodeID int64) *NodeInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.nodes[nodeID]
+}
+
+// Add registers or replaces a node's NodeInfo.
+func (m *NodeManager) Add(info *NodeInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodes[info.ID()] = info
+}
+
+// Remove unregisters a node, e.g. on stop or eviction.
+func (m *NodeManager) Remove(nodeID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.nodes, nodeID)
+}
@@ -0,0 +1,50 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeManager_GetAddRemove(t *testing.T) {
+	m := NewNodeManager()
+	assert.Nil(t, m.Get(1))
+
+	info := NewNodeInfo(1, "127.0.0.1:21123", map[string]string{"zone": "us-east-1a"})
+	m.Add(info)
+
+	got := m.Get(1)
+	assert.NotNil(t, got)
+	assert.EqualValues(t, 1, got.ID())
+	assert.Equal(t, "127.0.0.1:21123", got.Addr())
+	assert.Equal(t, "us-east-1a", got.Labels()["zone"])
+
+	m.Remove(1)
+	assert.Nil(t, m.Get(1))
+}
+
+func TestNodeInfo_SetStats(t *testing.T) {
+	info := NewNodeInfo(1, "127.0.0.1:21123", nil)
+	info.SetStats(NodeStats{InflightSearchCount: 3, SearchLatencyP99: 50 * time.Millisecond})
+
+	stats := info.Stats()
+	assert.Equal(t, 3, stats.InflightSearchCount)
+	assert.Equal(t, 50*time.Millisecond, stats.SearchLatencyP99)
+}
@@ -0,0 +1,125 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"time"
+)
+
+// defaultStaleReadMinSegmentRatio and defaultStaleReadMaxStaleness are the
+// EventualDataReady defaults. The request asked for these to be configurable
+// via paramtable.QueryCoordCfg, but that requires a paramtable field this
+// trimmed-down commit doesn't add; until that lands, callers needing
+// different tolerances must use WithMinSegmentRatio/WithMaxStaleness
+// explicitly.
+const (
+	defaultStaleReadMinSegmentRatio = 0.8
+	defaultStaleReadMaxStaleness    = 5 * time.Minute
+)
+
+// DataReadyMode controls how strictly CheckDelegatorDataReady treats a
+// delegator whose distribution is missing sealed segments from target.
+//
+// Scope note: the originating request asked for this to be selectable
+// per-request via a new shard-leaders RPC field and per-collection via meta,
+// falling back to StrictDataReady. Neither exists in this tree (no .proto
+// change and no meta.Collection field), so there is nothing to resolve a
+// mode against yet. WithDataReadyMode below is the only way to reach
+// EventualDataReady for now, and is unused until a caller is wired up to the
+// gRPC field and the collection default; both are left for a follow-up once
+// the proto/meta plumbing exists.
+type DataReadyMode int
+
+const (
+	// StrictDataReady requires every sealed segment in target to already be
+	// present in the leader's distribution. This is the historical behavior.
+	StrictDataReady DataReadyMode = iota
+	// EventualDataReady tolerates a bounded amount of staleness: the leader
+	// is readable as long as it holds at least MinSegmentRatio of the target
+	// segments and every missing segment is younger than MaxStaleness (i.e.
+	// still within normal rebalance/handoff propagation lag), so proxies can
+	// keep serving bounded-staleness queries instead of failing outright. A
+	// segment's age is measured from its start position timestamp, not from
+	// when it went missing; a missing segment whose age is MaxStaleness or
+	// more still fails readiness.
+	EventualDataReady
+)
+
+// checkDataReadyOptions configures the tolerance of CheckDelegatorDataReady.
+type checkDataReadyOptions struct {
+	mode            DataReadyMode
+	minSegmentRatio float64
+	maxStaleness    time.Duration
+	staleSegmentIDs *[]int64
+}
+
+// CheckDataReadyOption customizes checkDataReadyOptions.
+type CheckDataReadyOption func(*checkDataReadyOptions)
+
+// WithDataReadyMode overrides the default (strict) readiness mode.
+func WithDataReadyMode(mode DataReadyMode) CheckDataReadyOption {
+	return func(o *checkDataReadyOptions) { o.mode = mode }
+}
+
+// WithMinSegmentRatio overrides the minimum fraction of target segments that
+// must be present for a leader to be considered readable under
+// EventualDataReady.
+func WithMinSegmentRatio(ratio float64) CheckDataReadyOption {
+	return func(o *checkDataReadyOptions) { o.minSegmentRatio = ratio }
+}
+
+// WithMaxStaleness overrides the maximum age a missing segment may have
+// before it blocks readiness under EventualDataReady.
+func WithMaxStaleness(d time.Duration) CheckDataReadyOption {
+	return func(o *checkDataReadyOptions) { o.maxStaleness = d }
+}
+
+// WithStaleSegmentIDs makes CheckDelegatorDataReady append the IDs of the
+// segments it tolerated as stale into dst. It is additive and never clears
+// dst, so callers can reuse the same slice across multiple leaders. Passing
+// this option keeps CheckDelegatorDataReady's return type unchanged (error
+// only), so existing callers that only check the error are unaffected.
+func WithStaleSegmentIDs(dst *[]int64) CheckDataReadyOption {
+	return func(o *checkDataReadyOptions) { o.staleSegmentIDs = dst }
+}
+
+func defaultCheckDataReadyOptions() *checkDataReadyOptions {
+	return &checkDataReadyOptions{
+		mode:            StrictDataReady,
+		minSegmentRatio: defaultStaleReadMinSegmentRatio,
+		maxStaleness:    defaultStaleReadMaxStaleness,
+	}
+}
+
+// staleReadReady reports whether a leader holding (total-missing) of total
+// target segments still clears the minimum segment ratio required to serve
+// bounded-staleness reads.
+func staleReadReady(total, missing int, minSegmentRatio float64) bool {
+	if total == 0 {
+		return true
+	}
+	readyRatio := float64(total-missing) / float64(total)
+	return readyRatio >= minSegmentRatio
+}
+
+// withinStalenessTolerance reports whether a missing segment's age is still
+// within the tolerated propagation lag, i.e. it should be served as stale
+// rather than failing readiness. An age of maxStaleness or more is not
+// tolerated.
+func withinStalenessTolerance(age, maxStaleness time.Duration) bool {
+	return age < maxStaleness
+}
@@ -0,0 +1,43 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleReadReady(t *testing.T) {
+	assert.True(t, staleReadReady(10, 0, 0.8))
+	assert.True(t, staleReadReady(10, 1, 0.8), "9/10 ready clears an 0.8 ratio")
+	assert.False(t, staleReadReady(10, 3, 0.8), "7/10 ready misses an 0.8 ratio")
+	assert.True(t, staleReadReady(10, 2, 0.8), "exactly the minimum ratio is ready")
+	assert.True(t, staleReadReady(0, 0, 0.8), "no target segments is vacuously ready")
+}
+
+func TestWithinStalenessTolerance(t *testing.T) {
+	maxStaleness := 5 * time.Minute
+
+	// A missing segment younger than the bound is tolerated.
+	assert.True(t, withinStalenessTolerance(time.Minute, maxStaleness))
+	// A missing segment whose age equals or exceeds the bound is not
+	// tolerated.
+	assert.False(t, withinStalenessTolerance(maxStaleness, maxStaleness))
+	assert.False(t, withinStalenessTolerance(10*time.Minute, maxStaleness))
+}
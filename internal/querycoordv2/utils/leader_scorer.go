@@ -0,0 +1,102 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"math"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+)
+
+// LeaderScorer ranks shard leader candidates by load, lower score is
+// preferred. It also estimates a per-request serial cost, in milliseconds,
+// that proxies can use to weight latency-aware routing decisions.
+type LeaderScorer interface {
+	// Score returns the load score of leader and its estimated serial cost.
+	// A leader whose node is unavailable in nodeMgr gets the worst possible
+	// score so it sinks to the bottom of the ranking.
+	Score(nodeMgr *session.NodeManager, leader *meta.LeaderView) (score float64, serialCost float64)
+}
+
+// WeightedRoundRobin scores leaders with a weighted combination of inflight
+// search count, CPU usage, memory usage, recent p99 search latency and
+// segment count, so that nodes of different capacity share load smoothly
+// instead of receiving a strictly equal number of requests.
+type WeightedRoundRobin struct {
+	InflightWeight float64
+	CPUWeight      float64
+	MemoryWeight   float64
+	LatencyWeight  float64
+	SegmentWeight  float64
+}
+
+// NewWeightedRoundRobin returns a WeightedRoundRobin whose largest weight is
+// on inflight search count, with CPU/latency weighted next and segment count
+// weighted least. The weights apply to raw, unnormalized values (latency in
+// milliseconds), so which signal contributes most to a given score depends on
+// the magnitudes the node reports.
+func NewWeightedRoundRobin() *WeightedRoundRobin {
+	return &WeightedRoundRobin{
+		InflightWeight: 0.35,
+		CPUWeight:      0.25,
+		MemoryWeight:   0.15,
+		LatencyWeight:  0.20,
+		SegmentWeight:  0.05,
+	}
+}
+
+func (s *WeightedRoundRobin) Score(nodeMgr *session.NodeManager, leader *meta.LeaderView) (float64, float64) {
+	info := nodeMgr.Get(leader.ID)
+	if info == nil {
+		return math.MaxFloat64, math.MaxFloat64
+	}
+
+	stats := info.Stats()
+	serialCost := float64(stats.SearchLatencyP99.Milliseconds())
+	score := s.InflightWeight*float64(stats.InflightSearchCount) +
+		s.CPUWeight*stats.CPUUsage +
+		s.MemoryWeight*stats.MemoryUsage +
+		s.LatencyWeight*serialCost +
+		s.SegmentWeight*float64(len(leader.Segments))
+	return score, serialCost
+}
+
+// LeastLoaded scores leaders purely by their current inflight search count,
+// always routing to whichever node has the fewest outstanding searches.
+type LeastLoaded struct{}
+
+func (LeastLoaded) Score(nodeMgr *session.NodeManager, leader *meta.LeaderView) (float64, float64) {
+	info := nodeMgr.Get(leader.ID)
+	if info == nil {
+		return math.MaxFloat64, math.MaxFloat64
+	}
+
+	stats := info.Stats()
+	return float64(stats.InflightSearchCount), float64(stats.SearchLatencyP99.Milliseconds())
+}
+
+// getLeaderScorer returns the LeaderScorer GetShardLeadersWithChannels ranks
+// candidates with.
+//
+// Scope note: the request asked for this to be selectable via a new
+// paramtable.QueryCoordCfg.ShardLeaderScorer config field, which this tree
+// has no paramtable package to add; until that config plumbing exists,
+// WeightedRoundRobin is the only scorer in use.
+func getLeaderScorer() LeaderScorer {
+	return NewWeightedRoundRobin()
+}
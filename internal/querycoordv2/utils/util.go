@@ -19,6 +19,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"go.uber.org/multierr"
@@ -27,9 +28,11 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
 )
 
 func CheckNodeAvailable(nodeID int64, info *session.NodeInfo) error {
@@ -40,11 +43,23 @@ func CheckNodeAvailable(nodeID int64, info *session.NodeInfo) error {
 }
 
 // In a replica, a shard is available, if and only if:
-// 1. The leader is online
-// 2. All QueryNodes in the distribution are online
-// 3. The last heartbeat response time is within HeartbeatAvailableInterval for all QueryNodes(include leader) in the distribution
-// 4. All segments of the shard in target should be in the distribution
-func CheckDelegatorDataReady(nodeMgr *session.NodeManager, targetMgr meta.TargetManagerInterface, leader *meta.LeaderView, scope int32) error {
+//  1. The leader is online
+//  2. All QueryNodes in the distribution are online
+//  3. The last heartbeat response time is within HeartbeatAvailableInterval for all QueryNodes(include leader) in the distribution
+//  4. All segments of the shard in target should be in the distribution, unless
+//     EventualDataReady was requested via CheckDataReadyOption, in which case a
+//     bounded amount of missing-but-recent segments is tolerated and, when
+//     WithStaleSegmentIDs is passed, reported back to the caller.
+//
+// The return type is intentionally still a plain error so that existing
+// callers are unaffected; use WithStaleSegmentIDs to learn which segments
+// were served stale.
+func CheckDelegatorDataReady(nodeMgr *session.NodeManager, targetMgr meta.TargetManagerInterface, leader *meta.LeaderView, scope int32, opts ...CheckDataReadyOption) error {
+	options := defaultCheckDataReadyOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	log := log.Ctx(context.TODO()).
 		WithRateGroup(fmt.Sprintf("util.CheckDelegatorDataReady-%d", leader.CollectionID), 1, 60).
 		With(zap.Int64("leaderID", leader.ID), zap.Int64("collectionID", leader.CollectionID))
@@ -58,10 +73,15 @@ func CheckDelegatorDataReady(nodeMgr *session.NodeManager, targetMgr meta.Target
 	}
 
 	segmentDist := targetMgr.GetSealedSegmentsByChannel(context.TODO(), leader.CollectionID, leader.Channel, scope)
+	missing := make(map[int64]*datapb.SegmentInfo)
 	// Check whether segments are fully loaded
-	for segmentID := range segmentDist {
+	for segmentID, segment := range segmentDist {
 		version, exist := leader.Segments[segmentID]
 		if !exist {
+			if options.mode == EventualDataReady {
+				missing[segmentID] = segment
+				continue
+			}
 			log.RatedInfo(10, "leader is not available due to lack of segment", zap.Int64("segmentID", segmentID))
 			return merr.WrapErrSegmentLack(segmentID)
 		}
@@ -76,6 +96,34 @@ func CheckDelegatorDataReady(nodeMgr *session.NodeManager, targetMgr meta.Target
 			return err
 		}
 	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if !staleReadReady(len(segmentDist), len(missing), options.minSegmentRatio) {
+		log.RatedInfo(10, "leader is not available, too many segments missing for stale read",
+			zap.Int("missing", len(missing)), zap.Int("total", len(segmentDist)))
+		return merr.WrapErrServiceInternal("too many segments missing from leader view for stale read")
+	}
+
+	staleSegmentIDs := make([]int64, 0, len(missing))
+	for segmentID, segment := range missing {
+		createTime := tsoutil.PhysicalTime(segment.GetStartPosition().GetTimestamp())
+		age := time.Since(createTime)
+		if !withinStalenessTolerance(age, options.maxStaleness) {
+			log.RatedInfo(10, "leader is not available, missing segment has been absent too long for stale read",
+				zap.Int64("segmentID", segmentID), zap.Duration("age", age))
+			return merr.WrapErrSegmentLack(segmentID)
+		}
+		staleSegmentIDs = append(staleSegmentIDs, segmentID)
+	}
+	sort.Slice(staleSegmentIDs, func(i, j int) bool { return staleSegmentIDs[i] < staleSegmentIDs[j] })
+
+	if options.staleSegmentIDs != nil {
+		*options.staleSegmentIDs = append(*options.staleSegmentIDs, staleSegmentIDs...)
+	}
+
 	return nil
 }
 
@@ -102,8 +150,13 @@ func checkLoadStatus(ctx context.Context, m *meta.Meta, collectionID int64) erro
 }
 
 func GetShardLeadersWithChannels(ctx context.Context, m *meta.Meta, targetMgr meta.TargetManagerInterface, dist *meta.DistributionManager,
-	nodeMgr *session.NodeManager, collectionID int64, channels map[string]*meta.DmChannel,
+	nodeMgr *session.NodeManager, collectionID int64, channels map[string]*meta.DmChannel, opts ...LeaderSelectOption,
 ) ([]*querypb.ShardLeadersList, error) {
+	options := defaultLeaderSelectOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	ret := make([]*querypb.ShardLeadersList, 0)
 	for _, channel := range channels {
 		log := log.Ctx(ctx).With(zap.String("channel", channel.GetChannelName()))
@@ -130,19 +183,63 @@ func GetShardLeadersWithChannels(ctx context.Context, m *meta.Meta, targetMgr me
 			return nil, err
 		}
 
-		readableLeaders = filterDupLeaders(ctx, m.ReplicaManager, readableLeaders)
-		ids := make([]int64, 0, len(leaders))
-		addrs := make([]string, 0, len(leaders))
+		scorer := getLeaderScorer()
+		readableLeaders = filterDupLeaders(ctx, m.ReplicaManager, readableLeaders, nodeMgr, scorer)
+
+		candidates, zoneUnavailable := selectZoneCandidates(nodeMgr, readableLeaders, options)
+		if zoneUnavailable {
+			msg := fmt.Sprintf("channel %s has no leader in the preferred zone and fallback policy is strict", channel.GetChannelName())
+			log.Warn(msg)
+			return nil, merr.WrapErrChannelNotAvailable(channel.GetChannelName(), msg)
+		}
+		readableLeaders = candidates
+
+		// Drop leaders that aren't actually data-ready yet. Readiness always
+		// uses StrictDataReady here: selecting EventualDataReady per-request
+		// (gRPC field) or per-collection (meta.Collection field) requires
+		// proto/meta plumbing that doesn't exist in this tree yet, so that
+		// selection is left for a follow-up; see the scope note on
+		// DataReadyMode in consistency.go.
+		var staleSegmentIDs []int64
+		dataReadyLeaders := make(map[int64]*meta.LeaderView, len(readableLeaders))
+		for id, leader := range readableLeaders {
+			if err := CheckDelegatorDataReady(nodeMgr, targetMgr, leader, int32(querypb.DataScope_All),
+				WithStaleSegmentIDs(&staleSegmentIDs)); err != nil {
+				multierr.AppendInto(&channelErr, err)
+				continue
+			}
+			dataReadyLeaders[id] = leader
+		}
+		readableLeaders = dataReadyLeaders
+		if len(staleSegmentIDs) > 0 {
+			log.Info("serving channel with stale segments tolerated under eventual consistency",
+				zap.Int64s("staleSegmentIDs", staleSegmentIDs))
+		}
+
+		type scoredLeader struct {
+			id         int64
+			addr       string
+			score      float64
+			serialCost float64
+		}
+		scored := make([]scoredLeader, 0, len(readableLeaders))
 		for _, leader := range readableLeaders {
 			info := nodeMgr.Get(leader.ID)
-			if info != nil {
-				ids = append(ids, info.ID())
-				addrs = append(addrs, info.Addr())
+			if info == nil {
+				continue
 			}
+			score, serialCost := scorer.Score(nodeMgr, leader)
+			scored = append(scored, scoredLeader{id: info.ID(), addr: info.Addr(), score: score, serialCost: serialCost})
 		}
+		sort.Slice(scored, func(i, j int) bool {
+			if scored[i].score != scored[j].score {
+				return scored[i].score < scored[j].score
+			}
+			return scored[i].serialCost < scored[j].serialCost
+		})
 
 		// to avoid node down during GetShardLeaders
-		if len(ids) == 0 {
+		if len(scored) == 0 {
 			if channelErr == nil {
 				channelErr = merr.WrapErrChannelNotAvailable(channel.GetChannelName())
 			}
@@ -152,6 +249,22 @@ func GetShardLeadersWithChannels(ctx context.Context, m *meta.Meta, targetMgr me
 			return nil, err
 		}
 
+		// Scope note: the request asked for ShardLeadersList to carry
+		// per-leader Score and SerialCost fields, which requires a .proto
+		// change and regeneration that is out of scope for this commit (this
+		// tree has no .proto files at all). NodeIds/NodeAddrs are instead
+		// ordered best-score-first, so proxies that only understand the
+		// existing unordered contract keep working while ones that want the
+		// ranking can prefer scored[0]; this is a materially smaller feature
+		// than the per-leader numeric fields asked for, left for a follow-up
+		// once the proto change lands.
+		ids := make([]int64, 0, len(scored))
+		addrs := make([]string, 0, len(scored))
+		for _, l := range scored {
+			ids = append(ids, l.id)
+			addrs = append(addrs, l.addr)
+		}
+
 		ret = append(ret, &querypb.ShardLeadersList{
 			ChannelName: channel.GetChannelName(),
 			NodeIds:     ids,
@@ -162,7 +275,7 @@ func GetShardLeadersWithChannels(ctx context.Context, m *meta.Meta, targetMgr me
 	return ret, nil
 }
 
-func GetShardLeaders(ctx context.Context, m *meta.Meta, targetMgr meta.TargetManagerInterface, dist *meta.DistributionManager, nodeMgr *session.NodeManager, collectionID int64) ([]*querypb.ShardLeadersList, error) {
+func GetShardLeaders(ctx context.Context, m *meta.Meta, targetMgr meta.TargetManagerInterface, dist *meta.DistributionManager, nodeMgr *session.NodeManager, collectionID int64, opts ...LeaderSelectOption) ([]*querypb.ShardLeadersList, error) {
 	if err := checkLoadStatus(ctx, m, collectionID); err != nil {
 		return nil, err
 	}
@@ -174,7 +287,7 @@ func GetShardLeaders(ctx context.Context, m *meta.Meta, targetMgr meta.TargetMan
 		log.Ctx(ctx).Warn("failed to get channels", zap.Error(err))
 		return nil, err
 	}
-	return GetShardLeadersWithChannels(ctx, m, targetMgr, dist, nodeMgr, collectionID, channels)
+	return GetShardLeadersWithChannels(ctx, m, targetMgr, dist, nodeMgr, collectionID, channels, opts...)
 }
 
 // CheckCollectionsQueryable check all channels are watched and all segments are loaded for this collection
@@ -225,7 +338,7 @@ func checkCollectionQueryable(ctx context.Context, m *meta.Meta, targetMgr meta.
 	return nil
 }
 
-func filterDupLeaders(ctx context.Context, replicaManager *meta.ReplicaManager, leaders map[int64]*meta.LeaderView) map[int64]*meta.LeaderView {
+func filterDupLeaders(ctx context.Context, replicaManager *meta.ReplicaManager, leaders map[int64]*meta.LeaderView, nodeMgr *session.NodeManager, scorer LeaderScorer) map[int64]*meta.LeaderView {
 	type leaderID struct {
 		ReplicaID int64
 		Shard     string
@@ -239,8 +352,19 @@ func filterDupLeaders(ctx context.Context, replicaManager *meta.ReplicaManager,
 		}
 
 		id := leaderID{replica.GetID(), view.Channel}
-		if old, ok := newLeaders[id]; ok && old.Version > view.Version {
-			continue
+		if old, ok := newLeaders[id]; ok {
+			if old.Version > view.Version {
+				continue
+			}
+			// Same version on both candidates, break the tie with the scorer
+			// instead of keeping whichever happened to win the map iteration.
+			if old.Version == view.Version {
+				oldScore, _ := scorer.Score(nodeMgr, old)
+				newScore, _ := scorer.Score(nodeMgr, view)
+				if oldScore <= newScore {
+					continue
+				}
+			}
 		}
 
 		newLeaders[id] = view
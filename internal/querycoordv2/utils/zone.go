@@ -0,0 +1,170 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+)
+
+// ZoneFallbackPolicy controls how GetShardLeadersWithChannels behaves when
+// WithPreferredLabels narrows candidates down to an empty same-zone bucket,
+// e.g. during a zone outage.
+type ZoneFallbackPolicy int
+
+const (
+	// ZoneFallbackAny ignores zone affinity entirely once no same-zone
+	// candidate is available, spilling over directly to any other leader.
+	ZoneFallbackAny ZoneFallbackPolicy = iota
+	// ZoneFallbackPreferred relaxes the preferred labels one tier at a time
+	// (rack, then zone, then region, per labelRelaxationOrder) before
+	// spilling over to any other leader, so a rack outage still prefers
+	// same-zone leaders over an arbitrary one. This is the default.
+	ZoneFallbackPreferred
+	// ZoneFallbackStrict never spills over to another zone; callers get an
+	// error instead of cross-zone traffic.
+	ZoneFallbackStrict
+)
+
+// labelRelaxationOrder lists preferred-label keys from most to least
+// specific. ZoneFallbackPreferred drops one of these keys at a time, in this
+// order, retrying the match before giving up and spilling over to any leader.
+var labelRelaxationOrder = []string{"rack", "zone", "region"}
+
+// leaderSelectOptions configures topology-aware filtering for
+// GetShardLeadersWithChannels.
+type leaderSelectOptions struct {
+	preferredLabels map[string]string
+	fallbackPolicy  ZoneFallbackPolicy
+}
+
+// LeaderSelectOption customizes leaderSelectOptions.
+type LeaderSelectOption func(*leaderSelectOptions)
+
+// WithPreferredLabels makes GetShardLeadersWithChannels partition candidates
+// into same-zone and other-zone buckets based on labels (zone, rack, region,
+// ...), preferring the same-zone bucket before scoring.
+//
+// Scope note: this is the selection-side half of zone-aware routing only.
+// The request also asked for proxies to pass their own zone/rack/region down
+// so leader selection actually prefers same-zone traffic in production; no
+// proxy or RPC call site in this tree constructs this option yet, and
+// session.NodeInfo.Labels is only ever populated by tests, not by QueryNode
+// registration (that code doesn't exist in this tree either). Treat this as
+// foundation-only until both sides are wired up.
+func WithPreferredLabels(labels map[string]string) LeaderSelectOption {
+	return func(o *leaderSelectOptions) { o.preferredLabels = labels }
+}
+
+// WithZoneFallbackPolicy overrides the default ZoneFallbackPreferred policy.
+func WithZoneFallbackPolicy(policy ZoneFallbackPolicy) LeaderSelectOption {
+	return func(o *leaderSelectOptions) { o.fallbackPolicy = policy }
+}
+
+func defaultLeaderSelectOptions() *leaderSelectOptions {
+	return &leaderSelectOptions{fallbackPolicy: ZoneFallbackPreferred}
+}
+
+// matchesLabelsAtTier reports whether candidate carries every key/value pair
+// in preferred. An empty preferred set matches everything, which is also how
+// an exhausted relaxation tier naturally falls through to "any".
+func matchesLabelsAtTier(candidate, preferred map[string]string) bool {
+	if len(preferred) == 0 {
+		return true
+	}
+	for k, v := range preferred {
+		if candidate[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// relaxedPreferredLabels returns, in order, progressively looser copies of
+// preferred: first with the most specific known key in labelRelaxationOrder
+// dropped, then the next, and so on. Keys not present in preferred are
+// skipped. Callers retry matching against each tier in turn.
+func relaxedPreferredLabels(preferred map[string]string) []map[string]string {
+	tiers := make([]map[string]string, 0, len(labelRelaxationOrder))
+	current := preferred
+	for _, key := range labelRelaxationOrder {
+		if _, ok := current[key]; !ok {
+			continue
+		}
+		relaxed := make(map[string]string, len(current))
+		for k, v := range current {
+			if k != key {
+				relaxed[k] = v
+			}
+		}
+		tiers = append(tiers, relaxed)
+		current = relaxed
+	}
+	return tiers
+}
+
+// matchesPreferredLabels reports whether info's labels satisfy preferred.
+func matchesPreferredLabels(info *session.NodeInfo, preferred map[string]string) bool {
+	return matchesLabelsAtTier(info.Labels(), preferred)
+}
+
+// partitionByZone splits leaders into a matching bucket and a non-matching
+// bucket according to preferred. When preferred is empty, every leader
+// matches.
+func partitionByZone(nodeMgr *session.NodeManager, leaders map[int64]*meta.LeaderView, preferred map[string]string) (matched, unmatched map[int64]*meta.LeaderView) {
+	matched = make(map[int64]*meta.LeaderView, len(leaders))
+	unmatched = make(map[int64]*meta.LeaderView)
+	for id, leader := range leaders {
+		info := nodeMgr.Get(leader.ID)
+		if info != nil && matchesPreferredLabels(info, preferred) {
+			matched[id] = leader
+		} else {
+			unmatched[id] = leader
+		}
+	}
+	return matched, unmatched
+}
+
+// selectZoneCandidates applies options.fallbackPolicy to pick which bucket of
+// leaders GetShardLeadersWithChannels should score and return. It reports
+// whether the channel should be treated as unavailable (strict policy with no
+// same-zone candidates).
+func selectZoneCandidates(nodeMgr *session.NodeManager, leaders map[int64]*meta.LeaderView, options *leaderSelectOptions) (candidates map[int64]*meta.LeaderView, unavailable bool) {
+	if len(options.preferredLabels) == 0 {
+		return leaders, false
+	}
+
+	sameZone, otherZone := partitionByZone(nodeMgr, leaders, options.preferredLabels)
+	if len(sameZone) > 0 {
+		return sameZone, false
+	}
+
+	switch options.fallbackPolicy {
+	case ZoneFallbackStrict:
+		return nil, true
+	case ZoneFallbackPreferred:
+		for _, relaxed := range relaxedPreferredLabels(options.preferredLabels) {
+			tierMatch, _ := partitionByZone(nodeMgr, leaders, relaxed)
+			if len(tierMatch) > 0 {
+				return tierMatch, false
+			}
+		}
+		return otherZone, false
+	default: // ZoneFallbackAny
+		return otherZone, false
+	}
+}
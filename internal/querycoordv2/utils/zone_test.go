@@ -0,0 +1,61 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesLabelsAtTier(t *testing.T) {
+	candidate := map[string]string{"region": "us-east", "zone": "us-east-1a", "rack": "r1"}
+
+	assert.True(t, matchesLabelsAtTier(candidate, nil), "empty preference matches everything")
+	assert.True(t, matchesLabelsAtTier(candidate, map[string]string{"zone": "us-east-1a"}))
+	assert.False(t, matchesLabelsAtTier(candidate, map[string]string{"zone": "us-east-1b"}))
+	assert.False(t, matchesLabelsAtTier(candidate, map[string]string{"rack": "r1", "zone": "us-east-1b"}))
+}
+
+func TestRelaxedPreferredLabels(t *testing.T) {
+	preferred := map[string]string{"region": "us-east", "zone": "us-east-1a", "rack": "r1"}
+
+	tiers := relaxedPreferredLabels(preferred)
+	assert.Len(t, tiers, 3)
+
+	// Most specific key (rack) is dropped first, then zone, then region,
+	// per labelRelaxationOrder.
+	assert.NotContains(t, tiers[0], "rack")
+	assert.Contains(t, tiers[0], "zone")
+	assert.Contains(t, tiers[0], "region")
+
+	assert.NotContains(t, tiers[1], "rack")
+	assert.NotContains(t, tiers[1], "zone")
+	assert.Contains(t, tiers[1], "region")
+
+	assert.NotContains(t, tiers[2], "rack")
+	assert.NotContains(t, tiers[2], "zone")
+	assert.NotContains(t, tiers[2], "region")
+}
+
+func TestRelaxedPreferredLabels_SkipsAbsentKeys(t *testing.T) {
+	preferred := map[string]string{"zone": "us-east-1a"}
+
+	tiers := relaxedPreferredLabels(preferred)
+	assert.Len(t, tiers, 1, "only the one present key (zone) produces a relaxation tier")
+	assert.NotContains(t, tiers[0], "zone")
+}